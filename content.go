@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// sniffSize is how much of a file's head we read to decide whether it's
+// text before bothering to grep through it.
+const sniffSize = 8 * 1024
+
+// buildSizePredicate parses a find(1)-style size range: "+10M" (larger
+// than), "-1k" (smaller than), or a bare "512" (exactly). Recognized unit
+// suffixes are b/c (bytes), k, m, g (binary multiples); no suffix means
+// bytes.
+func buildSizePredicate(spec string) (Predicate, error) {
+	if spec == "" {
+		return nil, nil
+	}
+
+	cmp := byte('=')
+	rest := spec
+	if spec[0] == '+' || spec[0] == '-' {
+		cmp = spec[0]
+		rest = spec[1:]
+	}
+	if rest == "" {
+		return nil, fmt.Errorf("-size: invalid value %q", spec)
+	}
+
+	mult := int64(1)
+	numPart := rest
+	switch rest[len(rest)-1] {
+	case 'k', 'K':
+		mult = 1024
+		numPart = rest[:len(rest)-1]
+	case 'm', 'M':
+		mult = 1024 * 1024
+		numPart = rest[:len(rest)-1]
+	case 'g', 'G':
+		mult = 1024 * 1024 * 1024
+		numPart = rest[:len(rest)-1]
+	case 'b', 'B', 'c':
+		numPart = rest[:len(rest)-1]
+	}
+
+	n, err := strconv.ParseInt(numPart, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("-size: invalid value %q: %w", spec, err)
+	}
+	want := n * mult
+
+	return func(info os.FileInfo, path string) bool {
+		switch cmp {
+		case '+':
+			return info.Size() > want
+		case '-':
+			return info.Size() < want
+		default:
+			return info.Size() == want
+		}
+	}, nil
+}
+
+// buildNamePredicate matches info.Name() against a shell glob, optionally
+// case-insensitively (-iname).
+func buildNamePredicate(glob string, caseInsensitive bool) (Predicate, error) {
+	if glob == "" {
+		return nil, nil
+	}
+	if _, err := filepath.Match(glob, ""); err != nil {
+		return nil, fmt.Errorf("invalid glob %q: %w", glob, err)
+	}
+
+	pattern := glob
+	if caseInsensitive {
+		pattern = strings.ToLower(glob)
+	}
+
+	return func(info os.FileInfo, path string) bool {
+		name := info.Name()
+		if caseInsensitive {
+			name = strings.ToLower(name)
+		}
+		ok, _ := filepath.Match(pattern, name)
+		return ok
+	}, nil
+}
+
+// buildRegexPredicate matches the full path against a RE2 pattern.
+func buildRegexPredicate(pattern string) (Predicate, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("-regex: %w", err)
+	}
+	return func(info os.FileInfo, path string) bool {
+		return re.MatchString(path)
+	}, nil
+}
+
+// checkContains opens path and searches it for pattern when pattern is
+// non-empty. It reports ok=false for binary files, unreadable files, or
+// files with no match - any of which means the caller should skip this
+// result. When pattern is empty it's a no-op and always reports ok=true.
+// info must be the caller's already-stat'd FileInfo for path - checked
+// before os.Open so a FIFO or other non-regular file is skipped instead of
+// blocking the scan forever waiting for a writer.
+func checkContains(path string, info os.FileInfo, pattern string) (matches []Match, ok bool) {
+	if pattern == "" {
+		return nil, true
+	}
+	if !info.Mode().IsRegular() {
+		return nil, false
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, false
+	}
+	defer f.Close()
+
+	head := make([]byte, sniffSize)
+	n, _ := f.Read(head)
+	if looksBinary(head[:n]) {
+		return nil, false
+	}
+	if _, err := f.Seek(0, 0); err != nil {
+		return nil, false
+	}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		if strings.Contains(scanner.Text(), pattern) {
+			matches = append(matches, Match{Line: lineNo, Text: strings.TrimSpace(scanner.Text())})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		printWarning(fmt.Sprintf("-contains: %s: %v (line too long, file skipped)", path, err))
+		return nil, false
+	}
+	if len(matches) == 0 {
+		return nil, false
+	}
+	return matches, true
+}
+
+// looksBinary applies the usual NUL/UTF-8 sniff heuristic to a file's head.
+// head is just a fixed-size prefix of the file, not necessarily aligned to
+// a rune boundary, so a valid multi-byte rune straddling the end of head is
+// trimmed before validation instead of being misreported as invalid UTF-8.
+func looksBinary(head []byte) bool {
+	if bytes.IndexByte(head, 0) != -1 {
+		return true
+	}
+	return !utf8.Valid(trimIncompleteTrailingRune(head))
+}
+
+// trimIncompleteTrailingRune drops a trailing byte sequence that looks like
+// the start of a multi-byte UTF-8 rune cut short by the end of b.
+func trimIncompleteTrailingRune(b []byte) []byte {
+	for i := 1; i < utf8.UTFMax && i <= len(b); i++ {
+		c := b[len(b)-i]
+		if c&0xC0 == 0x80 {
+			continue // continuation byte - keep looking back for the lead byte
+		}
+		if runeByteLen(c) > i {
+			return b[:len(b)-i]
+		}
+		break
+	}
+	return b
+}
+
+// runeByteLen reports the total length of the UTF-8 sequence starting with
+// lead, or 1 if lead isn't a valid multi-byte lead byte.
+func runeByteLen(lead byte) int {
+	switch {
+	case lead&0x80 == 0x00:
+		return 1
+	case lead&0xE0 == 0xC0:
+		return 2
+	case lead&0xF0 == 0xE0:
+		return 3
+	case lead&0xF8 == 0xF0:
+		return 4
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTrimIncompleteTrailingRune(t *testing.T) {
+	// twoByteRune, threeByteRune, fourByteRune are valid, complete UTF-8
+	// encodings of single runes, spelled out as raw bytes (rather than a
+	// literal non-ASCII source character) so the test is unambiguous about
+	// exactly which bytes it's exercising.
+	twoByteRune := []byte{0xC3, 0xA9}              // U+00E9
+	threeByteRune := []byte{0xE2, 0x82, 0xAC}      // U+20AC
+	fourByteRune := []byte{0xF0, 0x9F, 0x98, 0x80} // U+1F600
+
+	tests := []struct {
+		name string
+		in   []byte
+		want []byte
+	}{
+		{"empty", []byte{}, []byte{}},
+		{"ascii only", []byte("hello"), []byte("hello")},
+		{"complete 2-byte rune", append([]byte("caf"), twoByteRune...), append([]byte("caf"), twoByteRune...)},
+		{"cut 2-byte rune (lead only)", append([]byte("caf"), twoByteRune[:1]...), []byte("caf")},
+		{"cut 3-byte rune (1 of 3)", append([]byte("x"), threeByteRune[:1]...), []byte("x")},
+		{"cut 3-byte rune (2 of 3)", append([]byte("x"), threeByteRune[:2]...), []byte("x")},
+		{"cut 4-byte rune (1 of 4)", append([]byte("x"), fourByteRune[:1]...), []byte("x")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := trimIncompleteTrailingRune(tt.in)
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("trimIncompleteTrailingRune(%v) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLooksBinaryTruncatedRune(t *testing.T) {
+	// A valid 2-byte rune (0xC3 0xA9) cut right after its lead byte, as
+	// would happen if it straddled a fixed-size sniff boundary.
+	head := append([]byte("some valid text"), 0xC3)
+	if looksBinary(head) {
+		t.Error("looksBinary reported a truncated-but-valid UTF-8 rune as binary")
+	}
+
+	if !looksBinary([]byte("binary\x00data")) {
+		t.Error("looksBinary missed an embedded NUL byte")
+	}
+
+	if !looksBinary([]byte{0xFF, 0xFE, 'A', 'B'}) {
+		t.Error("looksBinary missed genuinely invalid UTF-8")
+	}
+}
+
+// TestCheckContainsRuneAcrossSniffBoundary reproduces a multi-byte UTF-8
+// rune landing exactly across the sniffSize (8192) read boundary: the file
+// is valid UTF-8 throughout, but naively validating only the first
+// sniffSize bytes would see a truncated lead byte and misclassify the file
+// as binary, silently skipping -contains matches inside it.
+func TestCheckContainsRuneAcrossSniffBoundary(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "boundary.txt")
+
+	twoByteRune := []byte{0xC3, 0xA9} // U+00E9
+
+	var buf bytes.Buffer
+	for buf.Len() < sniffSize-1 {
+		buf.WriteByte('x')
+	}
+	// The rune's lead byte lands at index sniffSize-1, i.e. exactly the
+	// last byte of the sniffed head; its continuation byte falls just past
+	// the boundary.
+	buf.Write(twoByteRune)
+	buf.WriteString("\nNEEDLE_PATTERN\n")
+
+	if err := os.WriteFile(fp, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(fp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matches, ok := checkContains(fp, info, "NEEDLE_PATTERN")
+	if !ok {
+		t.Fatal("checkContains reported no match - file was misclassified as binary")
+	}
+	if len(matches) != 1 {
+		t.Errorf("got %d matches, want 1", len(matches))
+	}
+}
@@ -1,13 +1,15 @@
 package main
 
 import (
-	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
-	"strconv"
+	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"text/tabwriter"
 	"time"
 )
@@ -31,14 +33,59 @@ const (
 
 // Config holds command line arguments
 type Config struct {
-	Dir          string
-	Day          int
-	Month        int
-	Year         int
-	AllDate      string
-	Recursive    bool
-	Extensions   string
-	OutputFormat string
+	Dir            string
+	Recursive      bool
+	Extensions     string
+	OutputFormat   string
+	Workers        int
+	FollowSymlinks bool
+
+	// Date/time filters. After/Before/NewerThan/MTime/RefFile are the
+	// current interface; Day/Month/Year/AllDate are kept only so old
+	// invocations keep working - see buildLegacyDatePredicate.
+	After     string
+	Before    string
+	NewerThan string
+	MTime     string
+	RefFile   string
+
+	// Deprecated: use After/Before/NewerThan/MTime instead.
+	Day     int
+	Month   int
+	Year    int
+	AllDate string
+
+	// Content/name/size filters.
+	Size     string // find-style range, e.g. "+10M", "-1k"
+	Name     string // glob against the file name
+	IName    string // case-insensitive glob against the file name
+	Regex    string // RE2 against the full path
+	Contains string // substring searched for inside matching files
+
+	// Persistent index. UseIndex skips the filesystem walk entirely and
+	// queries an index previously built with the `index` subcommand;
+	// Refresh incrementally updates it first. See index.go.
+	UseIndex string
+	Refresh  bool
+
+	// Out is the output destination for non-tabular formats: "" picks an
+	// auto-generated filename, "-" means stdout. See output.go.
+	Out string
+
+	// Exclusion. Exclude/ExcludeFile are merged into a root-anchored layer
+	// applied everywhere; NoIgnore turns off the automatic per-directory
+	// .gitignore/.hgignore/.searchignore layers; Hidden, when false (the
+	// default), skips dotfiles/dot-directories entirely. See ignore.go.
+	Exclude     []string
+	ExcludeFile string
+	NoIgnore    bool
+	Hidden      bool
+}
+
+// Match is one line inside a file that satisfied -contains.
+type Match struct {
+	Line int    `json:"line"`
+	Text string `json:"text"`
 }
 
 // FileResult struct handles the output data structure
@@ -48,18 +95,46 @@ type FileResult struct {
 	LastModified string    `json:"last_modified"`
 	ModTimeRaw   time.Time `json:"-"`
 	Size         int64     `json:"size_bytes"`
+	Matches      []Match   `json:"matches,omitempty"`
 }
 
 var (
-	scannedCount int
-	matchCount   int
+	scannedCount int64
+	matchCount   int64
 	showLive     bool
+
+	// decorWriter is where banners, progress, and summary lines go. It's
+	// os.Stdout by default, but main() switches it to os.Stderr whenever
+	// the resolved output format's actual payload is itself going to
+	// stdout, so decorative output doesn't corrupt a piped result (e.g.
+	// `-o json -out - | jq .`). printTabular is unaffected: tabular's
+	// payload *is* os.Stdout, so it writes there directly regardless.
+	decorWriter io.Writer = os.Stdout
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "index" {
+		if err := runIndexCommand(os.Args[2:]); err != nil {
+			printError(err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
 	// 1. Parse Flags
 	config := parseFlags()
 
+	// Resolve the "-o -" shorthand (json written to stdout) before anything
+	// else looks at OutputFormat/Out, then route decorative/progress output
+	// to stderr whenever the resolved payload itself goes to stdout - that's
+	// what actually makes "-o json -out -" (or "-o -") composable with a
+	// shell pipeline like `| jq .`.
+	format, dest := resolveOutput(config)
+	config.OutputFormat, config.Out = format, dest
+	if dest == "-" && format != "tabular" {
+		decorWriter = os.Stderr
+	}
+
 	// Determine if we show live output
 	showLive = (config.OutputFormat == "json" || config.OutputFormat == "md")
 
@@ -96,18 +171,44 @@ func main() {
 	}
 
 	printDateFilter(config)
-	fmt.Println() // Space before scanning starts
+	printIgnoreFilter(config)
+	fmt.Fprintln(decorWriter) // Space before scanning starts
+
+	// 4. Build the filter chain and run the search
+	predicates, err := buildPredicates(config, allowedExts)
+	if err != nil {
+		printError(fmt.Sprintf("Invalid filter: %v", err))
+		os.Exit(1)
+	}
 
-	// 4. Run Search
 	var results []FileResult
-	var err error
+	var cache *fsCache
+
+	var ndjson *ndjsonSink
+	if config.OutputFormat == "ndjson" {
+		ndjson, err = newNDJSONSink(config)
+		if err != nil {
+			printError(fmt.Sprintf("Error opening ndjson destination: %v", err))
+			os.Exit(1)
+		}
+		liveSink = ndjson.write
+		defer func() {
+			liveSink = nil
+			ndjson.Close()
+		}()
+	}
 
 	startTime := time.Now()
 
-	if config.Recursive {
-		results, err = scanRecursive(config, allowedExts)
-	} else {
-		results, err = scanFlat(config, allowedExts)
+	switch {
+	case config.UseIndex != "":
+		results, err = runIndexedSearch(config, predicates)
+	case config.Recursive:
+		cache = newFSCache()
+		results, err = scanRecursive(config, predicates, cache)
+	default:
+		cache = newFSCache()
+		results, err = scanFlat(config, predicates, cache)
 	}
 
 	elapsed := time.Since(startTime)
@@ -115,7 +216,7 @@ func main() {
 	if err != nil {
 		// Clean the line in case we errored while scanning
 		if showLive {
-			fmt.Print(ClearLine + "\r")
+			fmt.Fprint(decorWriter, ClearLine+"\r")
 		}
 		printError(fmt.Sprintf("Error scanning: %v", err))
 		os.Exit(1)
@@ -124,10 +225,18 @@ func main() {
 	// 5. Print Summary
 	if showLive {
 		//  Clear the "Scanning..." line before printing summary
-		fmt.Print(ClearLine + "\r")
+		fmt.Fprint(decorWriter, ClearLine+"\r")
 	}
 
-	printSummary(scannedCount, len(results), elapsed)
+	printSummary(int(atomic.LoadInt64(&scannedCount)), len(results), elapsed)
+	if cache != nil {
+		printCacheStats(cache)
+	}
+
+	if ndjson != nil {
+		ndjson.announce()
+		return
+	}
 
 	// 6. Output Results
 	if len(results) == 0 {
@@ -141,27 +250,70 @@ func main() {
 func parseFlags() Config {
 	var c Config
 	flag.StringVar(&c.Dir, "dir", ".", "Target directory path")
-	flag.IntVar(&c.Day, "dt", 0, "Day (1-31)")
-	flag.IntVar(&c.Month, "m", 0, "Month (1-12)")
-	flag.IntVar(&c.Year, "y", 0, "Year (e.g. 2024)")
-	flag.StringVar(&c.AllDate, "all", "", "Complete date 'DD/M/YYYY' (e.g. 24/1/2026)")
+	flag.StringVar(&c.After, "after", "", "Only files modified after this time (YYYY-MM-DD or YYYY-MM-DDThh:mm)")
+	flag.StringVar(&c.Before, "before", "", "Only files modified before this time (YYYY-MM-DD or YYYY-MM-DDThh:mm)")
+	flag.StringVar(&c.NewerThan, "newer-than", "", "Only files modified within this long (e.g. 7d, 90m, 1.5h)")
+	flag.StringVar(&c.MTime, "mtime", "", "find(1)-style day offset: N, -N (within N days) or +N (older than N days)")
+	flag.StringVar(&c.RefFile, "ref-file", "", "Only files modified after the mtime of this reference file")
+	flag.IntVar(&c.Day, "dt", 0, "Deprecated: use -after/-before/-mtime. Day (1-31)")
+	flag.IntVar(&c.Month, "m", 0, "Deprecated: use -after/-before/-mtime. Month (1-12)")
+	flag.IntVar(&c.Year, "y", 0, "Deprecated: use -after/-before/-mtime. Year (e.g. 2024)")
+	flag.StringVar(&c.AllDate, "all", "", "Deprecated: use -after/-before. Complete date 'DD/M/YYYY' (e.g. 24/1/2026)")
 	flag.BoolVar(&c.Recursive, "r", false, "Enable recursive DFS scan")
 	flag.StringVar(&c.Extensions, "ex", "", "Comma separated extensions (e.g. go,py,txt)")
-	flag.StringVar(&c.OutputFormat, "o", "tabular", "Output format: tabular, json, md")
+	flag.StringVar(&c.OutputFormat, "o", "tabular", "Output format: tabular, json, md, csv, ndjson, sarif, html")
+	flag.StringVar(&c.Out, "out", "", "Output destination for non-tabular formats; '-' for stdout, default auto-generated filename")
+	flag.IntVar(&c.Workers, "workers", runtime.NumCPU(), "Number of worker goroutines for recursive scan")
+	flag.BoolVar(&c.FollowSymlinks, "follow-symlinks", false, "Follow symlinks (safe against loops via inode dedup on Unix; Windows has no dedup)")
+	flag.StringVar(&c.Size, "size", "", "find-style size range, e.g. +10M, -1k, 512")
+	flag.StringVar(&c.Name, "name", "", "Glob match against the file name, e.g. '*.go'")
+	flag.StringVar(&c.IName, "iname", "", "Case-insensitive glob match against the file name")
+	flag.StringVar(&c.Regex, "regex", "", "RE2 pattern matched against the full path")
+	flag.StringVar(&c.Contains, "contains", "", "Search inside matching text files for this substring")
+	flag.StringVar(&c.UseIndex, "use-index", "", "Query a persistent index (built with the 'index' subcommand) instead of walking the filesystem")
+	flag.BoolVar(&c.Refresh, "refresh", false, "With -use-index, incrementally rescan directories that changed since the index was written")
+	var excludes excludeList
+	flag.Var(&excludes, "exclude", "Glob to exclude, gitignore-style (repeatable)")
+	flag.StringVar(&c.ExcludeFile, "exclude-file", "", "File of gitignore-style patterns to exclude")
+	flag.BoolVar(&c.NoIgnore, "no-ignore", false, "Disable automatic .gitignore/.hgignore/.searchignore exclusion")
+	flag.BoolVar(&c.Hidden, "hidden", false, "Include dotfiles and dot-directories (skipped by default)")
 	flag.Parse()
+	c.Exclude = excludes
+	if c.Workers < 1 {
+		c.Workers = 1
+	}
 	return c
 }
 
 // scanFlat: Only looks at the top directory
-func scanFlat(c Config, exts map[string]bool) ([]FileResult, error) {
+func scanFlat(c Config, predicates []Predicate, cache *fsCache) ([]FileResult, error) {
 	var results []FileResult
 	entries, err := os.ReadDir(c.Dir)
 	if err != nil {
 		return nil, err
 	}
 
+	layers, err := baseIgnoreLayers(c)
+	if err != nil {
+		return nil, err
+	}
+	if !c.NoIgnore {
+		if own := loadDirIgnoreLayer(c.Dir); own != nil {
+			layers = append(layers, own)
+		}
+	}
+
 	for _, entry := range entries {
-		scannedCount++
+		if !c.Hidden && isHidden(entry.Name()) {
+			continue
+		}
+
+		full := filepath.Join(c.Dir, entry.Name())
+		if isIgnored(layers, full, entry.IsDir()) {
+			continue
+		}
+
+		atomic.AddInt64(&scannedCount, 1)
 
 		if entry.IsDir() {
 			continue
@@ -172,125 +324,303 @@ func scanFlat(c Config, exts map[string]bool) ([]FileResult, error) {
 			continue
 		}
 
+		if entry.Type()&os.ModeSymlink != 0 {
+			if !c.FollowSymlinks {
+				continue
+			}
+			if info, err = os.Stat(full); err != nil {
+				continue // broken symlink
+			}
+		}
+
+		if key, ok := direntKeyOf(info); ok {
+			if _, seen := cache.lookup(key); seen {
+				continue // already visited via another path (hardlink)
+			}
+			cache.store(key, infoToDirent(info))
+		}
+
 		if showLive {
-			printLiveScanning(filepath.Join(c.Dir, entry.Name()))
+			printLiveScanning(full)
 		}
 
-		if isMatch(info, c, exts) {
-			matchCount++
+		if isMatch(info, full, predicates) {
+			matches, ok := checkContains(full, info, c.Contains)
+			if !ok {
+				continue
+			}
+			atomic.AddInt64(&matchCount, 1)
 			if showLive {
-				printLiveMatch(filepath.Join(c.Dir, entry.Name()))
+				printLiveMatch(full)
+			}
+			result := buildResult(c.Dir, info, matches)
+			if liveSink != nil {
+				liveSink(result)
 			}
-			results = append(results, buildResult(c.Dir, info))
+			results = append(results, result)
 		}
 	}
 	return results, nil
 }
 
-// scanRecursive: Uses filepath.WalkDir for efficient DFS traversal
-func scanRecursive(c Config, exts map[string]bool) ([]FileResult, error) {
-	var results []FileResult
+// dirTask is one pending directory together with the ignore-rule layers
+// inherited from its ancestors - carrying the layers on the queue item
+// means a child never has to re-read or re-walk its parents' .gitignore
+// files to know what they excluded.
+type dirTask struct {
+	path   string
+	layers []*ignoreRules
+}
 
-	err := filepath.WalkDir(c.Dir, func(path string, d os.DirEntry, err error) error {
-		if err != nil {
-			return filepath.SkipDir
-		}
+// dirQueue is an unbounded work queue of pending directories shared by the
+// scanRecursive worker pool. It tracks how many pushed directories are still
+// unprocessed so pop() can unblock every worker once the tree is exhausted,
+// instead of spawning a goroutine per directory (which pegs the CPU on wide
+// trees without doing productive work).
+type dirQueue struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	items   []dirTask
+	pending int
+	closed  bool
+}
 
-		// Show directory scanning (Will be overwritten by file scanning)
-		if d.IsDir() {
-			if showLive {
-				printLiveDirectory(path)
-			}
-			return nil
-		}
+func newDirQueue() *dirQueue {
+	q := &dirQueue{}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+func (q *dirQueue) push(item dirTask) {
+	q.mu.Lock()
+	q.items = append(q.items, item)
+	q.pending++
+	q.cond.Signal()
+	q.mu.Unlock()
+}
 
-		scannedCount++
+// done marks one previously pushed directory as fully processed. Once no
+// directory is pending anywhere, the queue closes and every blocked worker
+// wakes up and exits.
+func (q *dirQueue) done() {
+	q.mu.Lock()
+	q.pending--
+	if q.pending == 0 {
+		q.closed = true
+		q.cond.Broadcast()
+	}
+	q.mu.Unlock()
+}
 
-		info, err := d.Info()
+func (q *dirQueue) pop() (dirTask, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for len(q.items) == 0 && !q.closed {
+		q.cond.Wait()
+	}
+	if len(q.items) == 0 {
+		return dirTask{}, false
+	}
+	item := q.items[0]
+	q.items = q.items[1:]
+	return item, true
+}
+
+// liveUpdate is a progress notification a worker wants printed. Workers
+// never print directly - they only run scanWorker concurrently, and
+// unsynchronized writes to the terminal would interleave. Sending through
+// this channel funnels every update through the single collector goroutine
+// in scanRecursive, the same way matches already did via out.
+type liveUpdate struct {
+	dir  bool // true for printLiveDirectory, false for printLiveScanning
+	path string
+}
+
+// scanWorker drains directories from q until the tree is exhausted, reading
+// each directory's entries, queueing subdirectories, and sending matches to
+// out. Exactly c.Workers of these run concurrently, regardless of how wide
+// or deep the tree is.
+func scanWorker(q *dirQueue, c Config, predicates []Predicate, cache *fsCache, out chan<- FileResult, ui chan<- liveUpdate) {
+	for {
+		task, ok := q.pop()
+		if !ok {
+			return
+		}
+		dir := task.path
+
+		entries, err := os.ReadDir(dir)
 		if err != nil {
-			return nil
+			q.done()
+			continue
 		}
 
-		// Update UI every 50 files to prevent flickering/slowing down
-		if showLive && scannedCount%50 == 0 {
-			printLiveScanning(path)
+		if showLive {
+			ui <- liveUpdate{dir: true, path: dir}
 		}
 
-		if isMatch(info, c, exts) {
-			matchCount++
-			if showLive {
-				printLiveMatch(path)
+		layers := task.layers
+		if !c.NoIgnore {
+			if own := loadDirIgnoreLayer(dir); own != nil {
+				layers = append(append([]*ignoreRules{}, task.layers...), own)
 			}
-			results = append(results, buildResult(filepath.Dir(path), info))
 		}
 
-		return nil
-	})
+		for _, entry := range entries {
+			if !c.Hidden && isHidden(entry.Name()) {
+				continue
+			}
 
-	return results, err
-}
+			full := filepath.Join(dir, entry.Name())
+			if isIgnored(layers, full, entry.IsDir()) {
+				continue
+			}
 
-func buildResult(dir string, info os.FileInfo) FileResult {
-	return FileResult{
-		Name:         info.Name(),
-		Path:         filepath.Join(dir, info.Name()),
-		LastModified: info.ModTime().Format("2006-01-02 15:04:05"),
-		ModTimeRaw:   info.ModTime(),
-		Size:         info.Size(),
+			info, err := entry.Info()
+			if err != nil {
+				continue
+			}
+
+			if entry.Type()&os.ModeSymlink != 0 {
+				if !c.FollowSymlinks {
+					continue
+				}
+				if info, err = os.Stat(full); err != nil {
+					continue // broken symlink
+				}
+			}
+
+			// Consult the dev+ino cache before recursing into, or stat-ing
+			// further, an entry we've already seen via another path -
+			// this is what makes -follow-symlinks safe against loops and
+			// keeps bind-mounted/hardlinked trees from being scanned twice.
+			if key, ok := direntKeyOf(info); ok {
+				if _, seen := cache.lookup(key); seen {
+					continue
+				}
+				cache.store(key, infoToDirent(info))
+			}
+
+			if info.IsDir() {
+				q.push(dirTask{path: full, layers: layers})
+				continue
+			}
+
+			n := atomic.AddInt64(&scannedCount, 1)
+
+			// Update UI every 50 files to prevent flickering/slowing down
+			if showLive && n%50 == 0 {
+				ui <- liveUpdate{dir: false, path: full}
+			}
+
+			if isMatch(info, full, predicates) {
+				matches, ok := checkContains(full, info, c.Contains)
+				if !ok {
+					continue
+				}
+				atomic.AddInt64(&matchCount, 1)
+				result := buildResult(dir, info, matches)
+				if liveSink != nil {
+					liveSink(result)
+				}
+				out <- result
+			}
+		}
+
+		q.done()
 	}
 }
 
-// isMatch: The brain of the filter logic
-func isMatch(info os.FileInfo, c Config, exts map[string]bool) bool {
-	// 1. Extension Check
-	if len(exts) > 0 {
-		ext := strings.ToLower(filepath.Ext(info.Name()))
-		if !exts[ext] {
-			return false
-		}
+// scanRecursive walks the tree with a bounded pool of c.Workers goroutines
+// instead of a single serial filepath.WalkDir. Directories are fanned out
+// through a dirQueue so the goroutine count stays fixed no matter how many
+// subdirectories are discovered, while FileResult matches stream back over
+// a channel that both the live UI and this collector consume.
+func scanRecursive(c Config, predicates []Predicate, cache *fsCache) ([]FileResult, error) {
+	layers, err := baseIgnoreLayers(c)
+	if err != nil {
+		return nil, err
 	}
 
-	// 2. Date Check
-	y, m, d := info.ModTime().Date()
+	q := newDirQueue()
+	out := make(chan FileResult, 256)
+	ui := make(chan liveUpdate, 256)
+	var results []FileResult
 
-	// Logic: -all flag takes priority
-	if c.AllDate != "" {
-		parts := strings.Split(c.AllDate, "/")
-		if len(parts) == 3 {
-			reqD, _ := strconv.Atoi(parts[0])
-			reqM, _ := strconv.Atoi(parts[1])
-			reqY, _ := strconv.Atoi(parts[2])
-
-			if reqD != d || reqM != int(m) || reqY != y {
-				return false
+	// Single consumer for everything printed to the terminal: matches from
+	// out and directory/scanning progress from ui. Draining both here -
+	// instead of letting workers print straight from scanWorker - is what
+	// keeps \r-prefixed status lines from interleaving across goroutines.
+	collected := make(chan struct{})
+	go func() {
+		for out != nil || ui != nil {
+			select {
+			case r, ok := <-out:
+				if !ok {
+					out = nil
+					continue
+				}
+				if showLive {
+					printLiveMatch(r.Path)
+				}
+				results = append(results, r)
+			case u, ok := <-ui:
+				if !ok {
+					ui = nil
+					continue
+				}
+				if u.dir {
+					printLiveDirectory(u.path)
+				} else {
+					printLiveScanning(u.path)
+				}
 			}
-			return true
 		}
+		close(collected)
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(c.Workers)
+	for i := 0; i < c.Workers; i++ {
+		go func() {
+			defer wg.Done()
+			scanWorker(q, c, predicates, cache, out, ui)
+		}()
 	}
 
-	// Partial Logic
-	if c.Year != 0 && c.Year != y {
-		return false
-	}
-	if c.Month != 0 && c.Month != int(m) {
-		return false
+	q.push(dirTask{path: c.Dir, layers: layers})
+	wg.Wait()
+	close(out)
+	close(ui)
+	<-collected
+
+	return results, nil
+}
+
+// baseIgnoreLayers builds the root-anchored layer from -exclude/-exclude-file,
+// applied at every directory level in addition to whatever .gitignore,
+// .hgignore, and .searchignore files are discovered along the way.
+func baseIgnoreLayers(c Config) ([]*ignoreRules, error) {
+	// Anchored to c.Dir exactly as given (not made absolute), so it lines
+	// up with the relative-or-absolute paths built from it everywhere else.
+	root := filepath.Clean(c.Dir)
+	layer, err := rulesFromGlobs(c.Exclude, c.ExcludeFile, root)
+	if err != nil {
+		return nil, err
 	}
-	if c.Day != 0 && c.Day != d {
-		return false
+	if layer == nil {
+		return nil, nil
 	}
-
-	return true
+	return []*ignoreRules{layer}, nil
 }
 
-// handleOutput routes to specific formatters
-func handleOutput(results []FileResult, c Config) {
-	switch strings.ToLower(c.OutputFormat) {
-	case "json":
-		saveJSON(results, c.Extensions)
-	case "md":
-		saveMarkdown(results, c.Extensions)
-	default:
-		printTabular(results)
+func buildResult(dir string, info os.FileInfo, matches []Match) FileResult {
+	return FileResult{
+		Name:         info.Name(),
+		Path:         filepath.Join(dir, info.Name()),
+		LastModified: info.ModTime().Format("2006-01-02 15:04:05"),
+		ModTimeRaw:   info.ModTime(),
+		Size:         info.Size(),
+		Matches:      matches,
 	}
 }
 
@@ -299,12 +629,34 @@ func printTabular(results []FileResult) {
 	printSuccess("Search Results:")
 	fmt.Println()
 
+	showMatches := false
+	for _, r := range results {
+		if len(r.Matches) > 0 {
+			showMatches = true
+			break
+		}
+	}
+
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintf(w, "%s%sDATE\tSIZE\tFILE\tPATH%s\n", ColorBold, ColorCyan, ColorReset)
-	fmt.Fprintf(w, "%s----\t----\t----\t----%s\n", ColorDim, ColorReset)
+	if showMatches {
+		fmt.Fprintf(w, "%s%sDATE\tSIZE\tFILE\tPATH\tMATCH%s\n", ColorBold, ColorCyan, ColorReset)
+		fmt.Fprintf(w, "%s----\t----\t----\t----\t-----%s\n", ColorDim, ColorReset)
+	} else {
+		fmt.Fprintf(w, "%s%sDATE\tSIZE\tFILE\tPATH%s\n", ColorBold, ColorCyan, ColorReset)
+		fmt.Fprintf(w, "%s----\t----\t----\t----%s\n", ColorDim, ColorReset)
+	}
 
 	for _, r := range results {
 		sizeStr := formatSize(r.Size)
+		if showMatches {
+			fmt.Fprintf(w, "%s%s%s\t%s%s%s\t%s\t%s%s%s\t%s\n",
+				ColorYellow, r.LastModified, ColorReset,
+				ColorGreen, sizeStr, ColorReset,
+				r.Name,
+				ColorDim, r.Path, ColorReset,
+				firstMatchPreview(r.Matches))
+			continue
+		}
 		fmt.Fprintf(w, "%s%s%s\t%s%s%s\t%s\t%s%s%s\n",
 			ColorYellow, r.LastModified, ColorReset,
 			ColorGreen, sizeStr, ColorReset,
@@ -315,52 +667,18 @@ func printTabular(results []FileResult) {
 	fmt.Println()
 }
 
-func saveJSON(results []FileResult, exts string) {
-	filename := generateFilename("output", exts, "json")
-	file, err := os.Create(filename)
-	if err != nil {
-		printError(fmt.Sprintf("Error creating file: %v", err))
-		return
+// firstMatchPreview renders a short "line:text" preview of the first
+// -contains match, truncated so it doesn't blow out the table width.
+func firstMatchPreview(matches []Match) string {
+	if len(matches) == 0 {
+		return ""
 	}
-	defer file.Close()
-
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	encoder.Encode(results)
-
-	printSuccess(fmt.Sprintf("JSON saved to: %s", filename))
-}
-
-func saveMarkdown(results []FileResult, exts string) {
-	filename := generateFilename("output", exts, "md")
-	file, err := os.Create(filename)
-	if err != nil {
-		printError(fmt.Sprintf("Error creating file: %v", err))
-		return
+	text := matches[0].Text
+	const maxLen = 50
+	if len(text) > maxLen {
+		text = text[:maxLen-3] + "..."
 	}
-	defer file.Close()
-
-	fmt.Fprintln(file, "# ðŸ” File Search Results")
-	fmt.Fprintf(file, "**Generated:** %s\n\n", time.Now().Format(time.RFC1123))
-	fmt.Fprintf(file, "**Total Files Found:** %d\n\n", len(results))
-	fmt.Fprintln(file, "| Last Modified | Size | File Name | Full Path |")
-	fmt.Fprintln(file, "|---|---|---|---|")
-
-	for _, r := range results {
-		safePath := strings.ReplaceAll(r.Path, "|", "\\|")
-		sizeStr := formatSize(r.Size)
-		fmt.Fprintf(file, "| %s | %s | %s | %s |\n", r.LastModified, sizeStr, r.Name, safePath)
-	}
-
-	printSuccess(fmt.Sprintf("Markdown saved to: %s", filename))
-}
-
-func generateFilename(prefix, exts, suffix string) string {
-	extPart := "all"
-	if exts != "" {
-		extPart = strings.ReplaceAll(exts, ",", "_")
-	}
-	return fmt.Sprintf("%s_%s.%s", prefix, extPart, suffix)
+	return fmt.Sprintf("%d:%s", matches[0].Line, text)
 }
 
 func formatSize(bytes int64) string {
@@ -383,27 +701,27 @@ func formatSize(bytes int64) string {
 // ============ PRINTING UTILITIES ============
 
 func printHeader() {
-	fmt.Printf("\n%s%s", ColorBold, ColorCyan)
-	fmt.Println("â•”â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•—")
-	fmt.Println("â•‘     FILE SEARCH CLI TOOL v1.1             â•‘")
-	fmt.Println("â•”â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•")
-	fmt.Printf("%s\n", ColorReset)
+	fmt.Fprintf(decorWriter, "\n%s%s", ColorBold, ColorCyan)
+	fmt.Fprintln(decorWriter, "â•”â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•—")
+	fmt.Fprintln(decorWriter, "â•‘     FILE SEARCH CLI TOOL v1.1             â•‘")
+	fmt.Fprintln(decorWriter, "â•”â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•")
+	fmt.Fprintf(decorWriter, "%s\n", ColorReset)
 }
 
 func printInfo(msg string) {
-	fmt.Printf("%sðŸ” %s%s\n", ColorBlue, msg, ColorReset)
+	fmt.Fprintf(decorWriter, "%sðŸ” %s%s\n", ColorBlue, msg, ColorReset)
 }
 
 func printSuccess(msg string) {
-	fmt.Printf("%sâœ… %s%s\n", ColorGreen, msg, ColorReset)
+	fmt.Fprintf(decorWriter, "%sâœ… %s%s\n", ColorGreen, msg, ColorReset)
 }
 
 func printWarning(msg string) {
-	fmt.Printf("%sâš ï¸  %s%s\n", ColorYellow, msg, ColorReset)
+	fmt.Fprintf(decorWriter, "%sâš ï¸  %s%s\n", ColorYellow, msg, ColorReset)
 }
 
 func printError(msg string) {
-	fmt.Printf("%sâŒ Error: %s%s\n", ColorRed, msg, ColorReset)
+	fmt.Fprintf(decorWriter, "%sâŒ Error: %s%s\n", ColorRed, msg, ColorReset)
 }
 
 // Clears the line before printing
@@ -414,7 +732,7 @@ func printLiveDirectory(path string) {
 		displayPath = "..." + path[len(path)-57:]
 	}
 	// ClearLine (\033[2K) ensures no garbage is left from previous longer lines
-	fmt.Printf("%s\r%sðŸ“‚ Scanning: %-60s%s", ClearLine, ColorCyan, displayPath, ColorReset)
+	fmt.Fprintf(decorWriter, "%s\r%sðŸ“‚ Scanning: %-60s%s", ClearLine, ColorCyan, displayPath, ColorReset)
 }
 
 // Clears the line before printing
@@ -424,8 +742,8 @@ func printLiveScanning(path string) {
 		displayPath = displayPath[:37] + "..."
 	}
 	// ClearLine (\033[2K) prevents the "ghost" text overlap
-	fmt.Printf("%s\r%sðŸ”Ž Checking: %-40s [Scanned: %d]%s",
-		ClearLine, ColorDim, displayPath, scannedCount, ColorReset)
+	fmt.Fprintf(decorWriter, "%s\r%sðŸ”Ž Checking: %-40s [Scanned: %d]%s",
+		ClearLine, ColorDim, displayPath, atomic.LoadInt64(&scannedCount), ColorReset)
 }
 
 // Prints with a NEWLINE (\n) so matches don't get overwritten
@@ -438,38 +756,85 @@ func printLiveMatch(path string) {
 	// 1. Clear the "Checking..." line
 	// 2. Print the match
 	// 3. Print newline so it sticks
-	fmt.Printf("%s\r%sâœ“ Match: %-40s [Found: %d]%s\n",
-		ClearLine, ColorGreen, displayPath, matchCount, ColorReset)
+	fmt.Fprintf(decorWriter, "%s\r%sâœ“ Match: %-40s [Found: %d]%s\n",
+		ClearLine, ColorGreen, displayPath, atomic.LoadInt64(&matchCount), ColorReset)
 }
 
 func printSummary(scanned, found int, elapsed time.Duration) {
-	fmt.Printf("\n%s%s", ColorBold, ColorWhite)
-	fmt.Println("â•”â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•—")
-	fmt.Printf("â•‘  SCAN COMPLETE                            â•‘\n")
-	fmt.Println("â• â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•£")
-	fmt.Printf("â•‘  Files Scanned: %-26d â•‘\n", scanned)
-	fmt.Printf("â•‘  Matches Found: %-26d â•‘\n", found)
-	fmt.Printf("â•‘  Time Taken:    %-26s â•‘\n", elapsed.Round(time.Millisecond))
-	fmt.Println("â•šâ•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•")
-	fmt.Printf("%s\n", ColorReset)
+	fmt.Fprintf(decorWriter, "\n%s%s", ColorBold, ColorWhite)
+	fmt.Fprintln(decorWriter, "â•”â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•—")
+	fmt.Fprintf(decorWriter, "â•‘  SCAN COMPLETE                            â•‘\n")
+	fmt.Fprintln(decorWriter, "â• â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•£")
+	fmt.Fprintf(decorWriter, "â•‘  Files Scanned: %-26d â•‘\n", scanned)
+	fmt.Fprintf(decorWriter, "â•‘  Matches Found: %-26d â•‘\n", found)
+	fmt.Fprintf(decorWriter, "â•‘  Time Taken:    %-26s â•‘\n", elapsed.Round(time.Millisecond))
+	fmt.Fprintln(decorWriter, "â•šâ•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•")
+	fmt.Fprintf(decorWriter, "%s\n", ColorReset)
+}
+
+// printCacheStats reports how much work the dev+ino cache saved by skipping
+// entries already visited via another path (hardlinks, followed symlinks).
+func printCacheStats(cache *fsCache) {
+	hits, misses := cache.stats()
+	printInfo(fmt.Sprintf("Cache: %d hits, %d misses (dedup skipped %d entries)", hits, misses, hits))
 }
 
 func printDateFilter(c Config) {
+	var parts []string
+	if c.After != "" {
+		parts = append(parts, fmt.Sprintf("after=%s", c.After))
+	}
+	if c.Before != "" {
+		parts = append(parts, fmt.Sprintf("before=%s", c.Before))
+	}
+	if c.NewerThan != "" {
+		parts = append(parts, fmt.Sprintf("newer-than=%s", c.NewerThan))
+	}
+	if c.MTime != "" {
+		parts = append(parts, fmt.Sprintf("mtime=%s", c.MTime))
+	}
+	if c.RefFile != "" {
+		parts = append(parts, fmt.Sprintf("ref-file=%s", c.RefFile))
+	}
+	if len(parts) > 0 {
+		printInfo(fmt.Sprintf("Date Filter: %s", strings.Join(parts, ", ")))
+		return
+	}
+
+	// Deprecated flags
 	if c.AllDate != "" {
-		printInfo(fmt.Sprintf("Date Filter: Exact match for %s", c.AllDate))
-	} else {
-		var parts []string
-		if c.Day != 0 {
-			parts = append(parts, fmt.Sprintf("Day=%d", c.Day))
-		}
-		if c.Month != 0 {
-			parts = append(parts, fmt.Sprintf("Month=%d", c.Month))
-		}
-		if c.Year != 0 {
-			parts = append(parts, fmt.Sprintf("Year=%d", c.Year))
-		}
-		if len(parts) > 0 {
-			printInfo(fmt.Sprintf("Date Filter: %s", strings.Join(parts, ", ")))
-		}
+		printInfo(fmt.Sprintf("Date Filter: Exact match for %s (deprecated -all)", c.AllDate))
+		return
+	}
+	if c.Day != 0 {
+		parts = append(parts, fmt.Sprintf("Day=%d", c.Day))
+	}
+	if c.Month != 0 {
+		parts = append(parts, fmt.Sprintf("Month=%d", c.Month))
+	}
+	if c.Year != 0 {
+		parts = append(parts, fmt.Sprintf("Year=%d", c.Year))
+	}
+	if len(parts) > 0 {
+		printInfo(fmt.Sprintf("Date Filter: %s (deprecated flags)", strings.Join(parts, ", ")))
+	}
+}
+
+func printIgnoreFilter(c Config) {
+	var parts []string
+	if c.NoIgnore {
+		parts = append(parts, "gitignore/hgignore/searchignore disabled")
+	}
+	if len(c.Exclude) > 0 {
+		parts = append(parts, fmt.Sprintf("exclude=%s", strings.Join(c.Exclude, ",")))
+	}
+	if c.ExcludeFile != "" {
+		parts = append(parts, fmt.Sprintf("exclude-file=%s", c.ExcludeFile))
+	}
+	if c.Hidden {
+		parts = append(parts, "hidden files included")
+	}
+	if len(parts) > 0 {
+		printInfo(fmt.Sprintf("Ignore: %s", strings.Join(parts, ", ")))
 	}
 }
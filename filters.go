@@ -0,0 +1,275 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Predicate reports whether a file matches one filter dimension. isMatch
+// ANDs a slice of these together, so new filters (size, permissions,
+// owner, ...) slot in without touching the others.
+type Predicate func(info os.FileInfo, path string) bool
+
+// isMatch reports whether info satisfies every predicate.
+func isMatch(info os.FileInfo, path string, predicates []Predicate) bool {
+	for _, p := range predicates {
+		if !p(info, path) {
+			return false
+		}
+	}
+	return true
+}
+
+// buildPredicates turns a Config into the Predicate chain scanFlat/
+// scanRecursive run against every file.
+func buildPredicates(c Config, exts map[string]bool) ([]Predicate, error) {
+	var preds []Predicate
+
+	if len(exts) > 0 {
+		preds = append(preds, func(info os.FileInfo, path string) bool {
+			return exts[strings.ToLower(filepath.Ext(info.Name()))]
+		})
+	}
+
+	timePred, err := buildTimePredicate(c)
+	if err != nil {
+		return nil, err
+	}
+	if timePred != nil {
+		preds = append(preds, timePred)
+	}
+
+	sizePred, err := buildSizePredicate(c.Size)
+	if err != nil {
+		return nil, err
+	}
+	if sizePred != nil {
+		preds = append(preds, sizePred)
+	}
+
+	namePred, err := buildNamePredicate(c.Name, false)
+	if err != nil {
+		return nil, err
+	}
+	if namePred != nil {
+		preds = append(preds, namePred)
+	}
+
+	inamePred, err := buildNamePredicate(c.IName, true)
+	if err != nil {
+		return nil, err
+	}
+	if inamePred != nil {
+		preds = append(preds, inamePred)
+	}
+
+	regexPred, err := buildRegexPredicate(c.Regex)
+	if err != nil {
+		return nil, err
+	}
+	if regexPred != nil {
+		preds = append(preds, regexPred)
+	}
+
+	return preds, nil
+}
+
+// buildTimePredicate resolves -after/-before/-newer-than/-mtime/-ref-file
+// into a single [after, before) window predicate. If none of those are set
+// it falls back to the deprecated -all/-dt/-m/-y triad so existing
+// invocations keep working.
+func buildTimePredicate(c Config) (Predicate, error) {
+	var after, before time.Time
+	bounded := false
+
+	if c.After != "" {
+		t, err := parseFlexibleTime(c.After)
+		if err != nil {
+			return nil, fmt.Errorf("-after: %w", err)
+		}
+		after = t
+		bounded = true
+	}
+	if c.Before != "" {
+		t, err := parseFlexibleTime(c.Before)
+		if err != nil {
+			return nil, fmt.Errorf("-before: %w", err)
+		}
+		before = t
+		bounded = true
+	}
+	if c.NewerThan != "" {
+		dur, err := parseRelativeDuration(c.NewerThan)
+		if err != nil {
+			return nil, fmt.Errorf("-newer-than: %w", err)
+		}
+		t := time.Now().Add(-dur)
+		if after.IsZero() || t.After(after) {
+			after = t
+		}
+		bounded = true
+	}
+	if c.MTime != "" {
+		a, b, err := parseMTime(c.MTime)
+		if err != nil {
+			return nil, fmt.Errorf("-mtime: %w", err)
+		}
+		if !a.IsZero() {
+			after = a
+		}
+		if !b.IsZero() {
+			before = b
+		}
+		bounded = true
+	}
+	if c.RefFile != "" {
+		fi, err := os.Stat(c.RefFile)
+		if err != nil {
+			return nil, fmt.Errorf("-ref-file: %w", err)
+		}
+		after = fi.ModTime()
+		bounded = true
+	}
+
+	if bounded {
+		a, b := after, before
+		return func(info os.FileInfo, path string) bool {
+			mt := info.ModTime()
+			if !a.IsZero() && mt.Before(a) {
+				return false
+			}
+			if !b.IsZero() && !mt.Before(b) {
+				return false
+			}
+			return true
+		}, nil
+	}
+
+	return buildLegacyDatePredicate(c)
+}
+
+// buildLegacyDatePredicate replicates the original day/month/year/all
+// matching so scripts written against v1.1 still behave the same for
+// well-formed dates. An -all value that isn't three "/"-separated fields is
+// reported as an error instead of being ignored as v1.1 did.
+//
+// Deprecated: prefer -after/-before/-newer-than/-mtime.
+func buildLegacyDatePredicate(c Config) (Predicate, error) {
+	if c.AllDate != "" {
+		parts := strings.Split(c.AllDate, "/")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("-all: invalid date %q (want DD/M/YYYY)", c.AllDate)
+		}
+		d, _ := strconv.Atoi(parts[0])
+		m, _ := strconv.Atoi(parts[1])
+		y, _ := strconv.Atoi(parts[2])
+
+		start := time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.Local)
+		end := start.AddDate(0, 0, 1)
+		return func(info os.FileInfo, path string) bool {
+			mt := info.ModTime()
+			return !mt.Before(start) && mt.Before(end)
+		}, nil
+	}
+
+	if c.Day == 0 && c.Month == 0 && c.Year == 0 {
+		return nil, nil
+	}
+
+	day, month, year := c.Day, c.Month, c.Year
+	return func(info os.FileInfo, path string) bool {
+		y, m, d := info.ModTime().Date()
+		if year != 0 && year != y {
+			return false
+		}
+		if month != 0 && month != int(m) {
+			return false
+		}
+		if day != 0 && day != d {
+			return false
+		}
+		return true
+	}, nil
+}
+
+// timeLayouts are tried in order for -after/-before/-ref-file-less absolute
+// timestamps, from most to least specific.
+var timeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+func parseFlexibleTime(spec string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, spec, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (want YYYY-MM-DD or YYYY-MM-DDThh:mm[:ss])", spec)
+}
+
+// parseRelativeDuration parses find(1)-adjacent shorthand like "7d", "90m",
+// "1.5h", accepting s/m/h/d/w units.
+func parseRelativeDuration(spec string) (time.Duration, error) {
+	if spec == "" {
+		return 0, nil
+	}
+	unit := spec[len(spec)-1]
+	var unitDur time.Duration
+	switch unit {
+	case 's':
+		unitDur = time.Second
+	case 'm':
+		unitDur = time.Minute
+	case 'h':
+		unitDur = time.Hour
+	case 'd':
+		unitDur = 24 * time.Hour
+	case 'w':
+		unitDur = 7 * 24 * time.Hour
+	default:
+		return 0, fmt.Errorf("invalid unit in %q (want s/m/h/d/w)", spec)
+	}
+
+	n, err := strconv.ParseFloat(spec[:len(spec)-1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", spec, err)
+	}
+	return time.Duration(n * float64(unitDur)), nil
+}
+
+// parseMTime implements find(1)-style "-mtime N" semantics relative to now:
+// "-3" means modified within the last 3 days, "+3" means modified more than
+// 3 days ago, and "3" means modified between 3 and 4 days ago.
+func parseMTime(spec string) (after, before time.Time, err error) {
+	sign := byte(0)
+	numPart := spec
+	if spec != "" && (spec[0] == '+' || spec[0] == '-') {
+		sign = spec[0]
+		numPart = spec[1:]
+	}
+
+	days, err := strconv.Atoi(numPart)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid value %q: %w", spec, err)
+	}
+
+	now := time.Now()
+	dur := time.Duration(days) * 24 * time.Hour
+
+	switch sign {
+	case '-':
+		after = now.Add(-dur)
+	case '+':
+		before = now.Add(-dur)
+	default:
+		before = now.Add(-dur)
+		after = before.Add(-24 * time.Hour)
+	}
+	return after, before, nil
+}
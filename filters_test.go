@@ -0,0 +1,109 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseFlexibleTime(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    time.Time
+		wantErr bool
+	}{
+		{"2026-01-15", time.Date(2026, 1, 15, 0, 0, 0, 0, time.Local), false},
+		{"2026-01-15T13:04", time.Date(2026, 1, 15, 13, 4, 0, 0, time.Local), false},
+		{"2026-01-15T13:04:05", time.Date(2026, 1, 15, 13, 4, 5, 0, time.Local), false},
+		{"not-a-date", time.Time{}, true},
+		{"", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := parseFlexibleTime(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFlexibleTime(%q) err = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if err == nil && !got.Equal(tt.want) {
+				t.Errorf("parseFlexibleTime(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRelativeDuration(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"30s", 30 * time.Second, false},
+		{"7d", 7 * 24 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{"1.5h", 90 * time.Minute, false},
+		{"2w", 14 * 24 * time.Hour, false},
+		{"5x", 0, true},
+		{"abc", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := parseRelativeDuration(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRelativeDuration(%q) err = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("parseRelativeDuration(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMTime(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		spec        string
+		wantErr     bool
+		checkAfter  bool // whether "after" should be non-zero
+		checkBefore bool // whether "before" should be non-zero
+	}{
+		{"-3", false, true, false},
+		{"+3", false, false, true},
+		{"3", false, true, true},
+		{"abc", true, false, false},
+		{"", true, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			after, before, err := parseMTime(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseMTime(%q) err = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if tt.checkAfter && after.IsZero() {
+				t.Errorf("parseMTime(%q) after is zero, want non-zero", tt.spec)
+			}
+			if tt.checkBefore && before.IsZero() {
+				t.Errorf("parseMTime(%q) before is zero, want non-zero", tt.spec)
+			}
+			if !tt.checkAfter && !after.IsZero() {
+				t.Errorf("parseMTime(%q) after = %v, want zero", tt.spec, after)
+			}
+			if !tt.checkBefore && !before.IsZero() {
+				t.Errorf("parseMTime(%q) before = %v, want zero", tt.spec, before)
+			}
+		})
+	}
+
+	// "-3" means "modified within the last 3 days" - after should be ~3 days ago.
+	after, _, _ := parseMTime("-3")
+	wantAfter := now.Add(-3 * 24 * time.Hour)
+	if diff := after.Sub(wantAfter); diff < -time.Second || diff > time.Second {
+		t.Errorf("parseMTime(-3) after = %v, want close to %v", after, wantAfter)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// direntKey identifies a file by its underlying device+inode (or platform
+// equivalent) rather than by path, so the same file reached through two
+// different paths - a hardlink, a followed symlink, a bind mount - resolves
+// to one entry.
+type direntKey struct {
+	Dev uint64
+	Ino uint64
+}
+
+// direntInfo is the subset of os.FileInfo worth caching.
+type direntInfo struct {
+	Name    string
+	Mode    os.FileMode
+	ModTime time.Time
+	Size    int64
+}
+
+// fsCache is a concurrency-safe dev+ino -> dirent cache consulted by
+// scanFlat/scanRecursive before re-stat'ing or re-walking an entry. It also
+// doubles as the dedup mechanism that stops symlink loops and repeated
+// hardlinks from being scanned more than once.
+type fsCache struct {
+	mu      sync.RWMutex
+	entries map[direntKey]direntInfo
+	hits    int64
+	misses  int64
+}
+
+func newFSCache() *fsCache {
+	return &fsCache{entries: make(map[direntKey]direntInfo)}
+}
+
+// lookup reports whether key has already been visited, recording a hit or
+// miss for the summary block either way. A miss marks key as visited under
+// the same lock, so two workers reaching one inode via different paths
+// can't both see a miss.
+func (c *fsCache) lookup(key direntKey) (direntInfo, bool) {
+	c.mu.Lock()
+	info, ok := c.entries[key]
+	if !ok {
+		c.entries[key] = direntInfo{}
+	}
+	c.mu.Unlock()
+
+	if ok {
+		atomic.AddInt64(&c.hits, 1)
+	} else {
+		atomic.AddInt64(&c.misses, 1)
+	}
+	return info, ok
+}
+
+func (c *fsCache) store(key direntKey, info direntInfo) {
+	c.mu.Lock()
+	c.entries[key] = info
+	c.mu.Unlock()
+}
+
+func (c *fsCache) stats() (hits, misses int64) {
+	return atomic.LoadInt64(&c.hits), atomic.LoadInt64(&c.misses)
+}
+
+func infoToDirent(info os.FileInfo) direntInfo {
+	return direntInfo{
+		Name:    info.Name(),
+		Mode:    info.Mode(),
+		ModTime: info.ModTime(),
+		Size:    info.Size(),
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// direntKeyOf extracts the device+inode pair backing info from its
+// syscall.Stat_t, as populated by os.Lstat/os.Stat on Unix.
+func direntKeyOf(info os.FileInfo) (direntKey, bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return direntKey{}, false
+	}
+	return direntKey{Dev: uint64(st.Dev), Ino: st.Ino}, true
+}
@@ -0,0 +1,14 @@
+//go:build windows
+
+package main
+
+import "os"
+
+// direntKeyOf would normally dedup via GetFileInformationByHandle's volume
+// serial number + file index, but that requires an open handle and
+// os.FileInfo doesn't carry one. Opening every entry just to dedup it would
+// cost more than the walk it's meant to save, so Windows runs without
+// dev+ino dedup: every entry is treated as unseen.
+func direntKeyOf(info os.FileInfo) (direntKey, bool) {
+	return direntKey{}, false
+}
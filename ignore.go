@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ignorePattern is one parsed line from a .gitignore-style file, or one
+// -exclude glob given directly on the command line.
+type ignorePattern struct {
+	pattern  string // with leading/trailing slashes already stripped
+	negate   bool   // line started with "!"
+	dirOnly  bool   // line ended with "/" - only matches directories
+	anchored bool   // pattern contained a "/" (other than a trailing one),
+	// so it's matched against the whole relative path instead of just the
+	// basename
+}
+
+// ignoreRules is one layer of patterns anchored to the directory that
+// defined them - a .gitignore's patterns are always matched relative to
+// the directory it lives in, no matter how deep the entry being tested is.
+type ignoreRules struct {
+	anchorDir string
+	patterns  []ignorePattern
+}
+
+// parseIgnoreLine turns one line of a gitignore-style file into a pattern.
+// Blank lines and comments (lines starting with "#") are not patterns.
+func parseIgnoreLine(line string) (ignorePattern, bool) {
+	line = strings.TrimRight(line, "\r\n")
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+		return ignorePattern{}, false
+	}
+
+	var p ignorePattern
+	if strings.HasPrefix(trimmed, "!") {
+		p.negate = true
+		trimmed = trimmed[1:]
+	}
+	if strings.HasSuffix(trimmed, "/") {
+		p.dirOnly = true
+		trimmed = strings.TrimSuffix(trimmed, "/")
+	}
+	p.anchored = strings.Contains(trimmed, "/")
+	trimmed = strings.TrimPrefix(trimmed, "/")
+	if trimmed == "" {
+		return ignorePattern{}, false
+	}
+	p.pattern = trimmed
+	return p, true
+}
+
+// rulesFromLines builds one ignoreRules layer anchored at dir from a slice
+// of raw (unparsed) lines.
+func rulesFromLines(lines []string, dir string) *ignoreRules {
+	r := &ignoreRules{anchorDir: dir}
+	for _, line := range lines {
+		if p, ok := parseIgnoreLine(line); ok {
+			r.patterns = append(r.patterns, p)
+		}
+	}
+	if len(r.patterns) == 0 {
+		return nil
+	}
+	return r
+}
+
+// scanLines splits r into individual lines.
+func scanLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
+
+// readLines reads path's contents as individual lines. A missing file is
+// not an error - callers just get no patterns from it.
+func readLines(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	return scanLines(f)
+}
+
+// ignoreFileNames are read, in this order, out of every directory visited
+// during a recursive scan (unless -no-ignore is set). Later files win over
+// earlier ones for the same directory, same as later lines winning within
+// a single file.
+var ignoreFileNames = []string{".gitignore", ".hgignore", ".searchignore"}
+
+// loadDirIgnoreLayer reads whichever of ignoreFileNames exist in dir and
+// merges them into a single layer anchored at dir, or nil if none exist.
+func loadDirIgnoreLayer(dir string) *ignoreRules {
+	var lines []string
+	for _, name := range ignoreFileNames {
+		fileLines, err := readLines(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		lines = append(lines, fileLines...)
+	}
+	return rulesFromLines(lines, dir)
+}
+
+// rulesFromGlobs turns -exclude values (and an optional -exclude-file) into
+// the layer applied at every directory level, anchored at root. Unlike the
+// per-directory .gitignore/.hgignore/.searchignore files (which are normally
+// absent and that's fine), -exclude-file is a path the user named explicitly,
+// so a typo should be reported the same way -ref-file reports one.
+func rulesFromGlobs(globs []string, excludeFile string, root string) (*ignoreRules, error) {
+	lines := append([]string{}, globs...)
+	if excludeFile != "" {
+		f, err := os.Open(excludeFile)
+		if err != nil {
+			return nil, fmt.Errorf("-exclude-file: %w", err)
+		}
+		defer f.Close()
+		fileLines, err := scanLines(f)
+		if err != nil {
+			return nil, fmt.Errorf("-exclude-file: %w", err)
+		}
+		lines = append(lines, fileLines...)
+	}
+	return rulesFromLines(lines, root), nil
+}
+
+// globMatch matches a "/"-separated gitignore pattern (which may contain
+// "**") against a "/"-separated relative path.
+func globMatch(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pat, name []string) bool {
+	if len(pat) == 0 {
+		return len(name) == 0
+	}
+	if pat[0] == "**" {
+		if matchSegments(pat[1:], name) {
+			return true
+		}
+		if len(name) == 0 {
+			return false
+		}
+		return matchSegments(pat, name[1:])
+	}
+	if len(name) == 0 {
+		return false
+	}
+	ok, err := path.Match(pat[0], name[0])
+	if err != nil || !ok {
+		return false
+	}
+	return matchSegments(pat[1:], name[1:])
+}
+
+func (p ignorePattern) matches(relSlash string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+	if p.anchored {
+		return globMatch(p.pattern, relSlash)
+	}
+	return globMatch(p.pattern, path.Base(relSlash))
+}
+
+// isIgnored walks every layer in order (outermost directory first,
+// innermost last) so that a more specific .gitignore can re-include
+// (via "!") what a parent excluded - the last pattern that matches any
+// layer decides the outcome.
+func isIgnored(layers []*ignoreRules, absPath string, isDir bool) bool {
+	ignored := false
+	for _, layer := range layers {
+		if layer == nil {
+			continue
+		}
+		rel, err := filepath.Rel(layer.anchorDir, absPath)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		relSlash := filepath.ToSlash(rel)
+		for _, p := range layer.patterns {
+			if p.matches(relSlash, isDir) {
+				ignored = !p.negate
+			}
+		}
+	}
+	return ignored
+}
+
+// isHidden reports whether name is a dotfile/dot-directory, excluding the
+// "." and ".." entries themselves which os.ReadDir never returns anyway.
+func isHidden(name string) bool {
+	return strings.HasPrefix(name, ".") && name != "." && name != ".."
+}
+
+// excludeList collects repeated -exclude flag values.
+type excludeList []string
+
+func (e *excludeList) String() string {
+	return strings.Join(*e, ",")
+}
+
+func (e *excludeList) Set(value string) error {
+	*e = append(*e, value)
+	return nil
+}
@@ -0,0 +1,124 @@
+package main
+
+import "testing"
+
+func TestGlobMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "main.py", false},
+		{"build", "build", true},
+		{"build", "src/build", false},
+		{"**/build", "build", true},
+		{"**/build", "src/build", true},
+		{"**/build", "a/b/c/build", true},
+		{"src/**", "src/a/b/c", true},
+		{"src/**/*.go", "src/a/b/main.go", true},
+		{"src/**/*.go", "src/a/b/main.py", false},
+		{"a/**/b", "a/b", true},
+		{"a/**/b", "a/x/y/b", true},
+		{"a/**/b", "a/x/y/c", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"_"+tt.name, func(t *testing.T) {
+			if got := globMatch(tt.pattern, tt.name); got != tt.want {
+				t.Errorf("globMatch(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseIgnoreLine(t *testing.T) {
+	tests := []struct {
+		line        string
+		wantOK      bool
+		wantPattern string
+		wantNegate  bool
+		wantDirOnly bool
+		wantAnchor  bool
+	}{
+		{"", false, "", false, false, false},
+		{"# a comment", false, "", false, false, false},
+		{"  ", false, "", false, false, false},
+		{"*.log", true, "*.log", false, false, false},
+		{"!keep.log", true, "keep.log", true, false, false},
+		{"build/", true, "build", false, true, false},
+		{"/root-only.txt", true, "root-only.txt", false, false, true},
+		{"a/b/c", true, "a/b/c", false, false, true},
+		{"!a/b/", true, "a/b", true, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			p, ok := parseIgnoreLine(tt.line)
+			if ok != tt.wantOK {
+				t.Fatalf("parseIgnoreLine(%q) ok = %v, want %v", tt.line, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if p.pattern != tt.wantPattern || p.negate != tt.wantNegate || p.dirOnly != tt.wantDirOnly || p.anchored != tt.wantAnchor {
+				t.Errorf("parseIgnoreLine(%q) = %+v, want pattern=%q negate=%v dirOnly=%v anchored=%v",
+					tt.line, p, tt.wantPattern, tt.wantNegate, tt.wantDirOnly, tt.wantAnchor)
+			}
+		})
+	}
+}
+
+func TestIgnorePatternMatches(t *testing.T) {
+	anchored, _ := parseIgnoreLine("src/*.go")
+	basename, _ := parseIgnoreLine("*.log")
+	dirOnly, _ := parseIgnoreLine("build/")
+
+	tests := []struct {
+		name  string
+		p     ignorePattern
+		rel   string
+		isDir bool
+		want  bool
+	}{
+		{"anchored match", anchored, "src/main.go", false, true},
+		{"anchored no match elsewhere", anchored, "lib/main.go", false, false},
+		{"basename matches anywhere", basename, "a/b/debug.log", false, true},
+		{"dirOnly rejects file", dirOnly, "build", false, false},
+		{"dirOnly accepts dir", dirOnly, "build", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.matches(tt.rel, tt.isDir); got != tt.want {
+				t.Errorf("matches(%q, %v) = %v, want %v", tt.rel, tt.isDir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsIgnored(t *testing.T) {
+	root := rulesFromLines([]string{"*.log", "!important.log"}, "/repo")
+	sub := rulesFromLines([]string{"*.tmp"}, "/repo/sub")
+	layers := []*ignoreRules{root, sub}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/repo/debug.log", true},
+		{"/repo/important.log", false},
+		{"/repo/sub/cache.tmp", true},
+		{"/repo/sub/important.log", false},
+		{"/repo/main.go", false},
+		{"/outside/debug.log", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := isIgnored(layers, tt.path, false); got != tt.want {
+				t.Errorf("isIgnored(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,350 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+// logRecord is one line of an on-disk index: an append-only log keyed by
+// path. The last record written for a path wins; a Deleted record drops it
+// from the in-memory Index. "dir" records exist only so -refresh can tell
+// whether a directory changed without re-walking the whole tree.
+type logRecord struct {
+	Kind    string    `json:"kind"` // "file" or "dir"
+	Path    string    `json:"path"`
+	Dev     uint64    `json:"dev,omitempty"`
+	Ino     uint64    `json:"ino,omitempty"`
+	Size    int64     `json:"size,omitempty"`
+	ModTime time.Time `json:"mtime"`
+	SHA256  string    `json:"sha256,omitempty"`
+	Deleted bool      `json:"deleted,omitempty"`
+}
+
+// Index is the replayed, in-memory view of an index log.
+type Index struct {
+	Files map[string]logRecord
+	Dirs  map[string]logRecord
+}
+
+func newIndex() *Index {
+	return &Index{Files: make(map[string]logRecord), Dirs: make(map[string]logRecord)}
+}
+
+func (idx *Index) apply(rec logRecord) {
+	target := idx.Files
+	if rec.Kind == "dir" {
+		target = idx.Dirs
+	}
+	if rec.Deleted {
+		delete(target, rec.Path)
+		return
+	}
+	target[rec.Path] = rec
+}
+
+// filesUnder returns every indexed file path directly inside dir.
+func (idx *Index) filesUnder(dir string) []string {
+	var paths []string
+	for p := range idx.Files {
+		if filepath.Dir(p) == dir {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// readIndex replays every record in the log at path into an Index.
+func readIndex(path string) (*Index, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	idx := newIndex()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var rec logRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return nil, fmt.Errorf("corrupt index record: %w", err)
+		}
+		idx.apply(rec)
+	}
+	return idx, scanner.Err()
+}
+
+// appendIndex appends records to the log at path, creating it if absent.
+func appendIndex(path string, records []logRecord) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	for _, rec := range records {
+		if err := enc.Encode(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func fileRecord(path string, info os.FileInfo, hash bool) logRecord {
+	rec := logRecord{Kind: "file", Path: path, Size: info.Size(), ModTime: info.ModTime()}
+	if key, ok := direntKeyOf(info); ok {
+		rec.Dev, rec.Ino = key.Dev, key.Ino
+	}
+	if hash {
+		if sum, err := sha256File(path); err == nil {
+			rec.SHA256 = sum
+		}
+	}
+	return rec
+}
+
+func dirRecord(path string, info os.FileInfo) logRecord {
+	rec := logRecord{Kind: "dir", Path: path, ModTime: info.ModTime()}
+	if key, ok := direntKeyOf(info); ok {
+		rec.Dev, rec.Ino = key.Dev, key.Ino
+	}
+	return rec
+}
+
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// buildIndexRecords walks root and returns one record per directory and
+// file found. Used both for a fresh `index` build and to backfill a
+// subtree discovered mid-refresh.
+func buildIndexRecords(root string, hash bool) ([]logRecord, error) {
+	var records []logRecord
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			records = append(records, dirRecord(path, info))
+			return nil
+		}
+		records = append(records, fileRecord(path, info, hash))
+		return nil
+	})
+	return records, err
+}
+
+// refreshIndexRecords compares every directory the index already knows
+// about against its current on-disk mtime and only fully re-reads
+// directories that changed, so -refresh costs roughly O(changed dirs) plus
+// O(known files) rather than a full re-walk of the tree. A directory's own
+// mtime only moves on an add/remove/rename of its entries - not on an
+// in-place edit of an existing file's content - so even an "unchanged"
+// directory still needs its known files re-stat'd individually to catch
+// that case.
+func refreshIndexRecords(idx *Index, hash bool) ([]logRecord, error) {
+	var records []logRecord
+	now := time.Now()
+
+	for dirPath, dirRec := range idx.Dirs {
+		fi, err := os.Stat(dirPath)
+		if os.IsNotExist(err) {
+			records = append(records, logRecord{Kind: "dir", Path: dirPath, Deleted: true, ModTime: now})
+			for _, fp := range idx.filesUnder(dirPath) {
+				records = append(records, logRecord{Kind: "file", Path: fp, Deleted: true, ModTime: now})
+			}
+			continue
+		}
+		if err != nil {
+			continue // unreadable
+		}
+		if fi.ModTime().Equal(dirRec.ModTime) {
+			records = append(records, refreshUnchangedDirFiles(idx, dirPath, hash)...)
+			continue
+		}
+
+		entries, err := os.ReadDir(dirPath)
+		if err != nil {
+			continue
+		}
+
+		seen := make(map[string]bool)
+		for _, e := range entries {
+			full := filepath.Join(dirPath, e.Name())
+
+			if e.IsDir() {
+				if _, known := idx.Dirs[full]; !known {
+					// Brand-new subtree: nothing to diff against yet, so
+					// backfill it in full rather than guessing.
+					if sub, err := buildIndexRecords(full, hash); err == nil {
+						records = append(records, sub...)
+					}
+				}
+				continue
+			}
+
+			info, err := e.Info()
+			if err != nil {
+				continue
+			}
+			seen[full] = true
+			records = append(records, fileRecord(full, info, hash))
+		}
+
+		for _, fp := range idx.filesUnder(dirPath) {
+			if !seen[fp] {
+				records = append(records, logRecord{Kind: "file", Path: fp, Deleted: true, ModTime: now})
+			}
+		}
+
+		records = append(records, dirRecord(dirPath, fi))
+	}
+
+	return records, nil
+}
+
+// refreshUnchangedDirFiles re-stats every file the index already knows
+// about directly inside dirPath and returns an updated record for any whose
+// size or mtime no longer matches what's indexed, or a deletion record if
+// it's gone. Used for directories whose own mtime hasn't moved, since that
+// alone doesn't rule out an existing file having been edited in place.
+func refreshUnchangedDirFiles(idx *Index, dirPath string, hash bool) []logRecord {
+	var records []logRecord
+	now := time.Now()
+
+	for _, fp := range idx.filesUnder(dirPath) {
+		rec := idx.Files[fp]
+		info, err := os.Stat(fp)
+		if os.IsNotExist(err) {
+			records = append(records, logRecord{Kind: "file", Path: fp, Deleted: true, ModTime: now})
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if info.Size() == rec.Size && info.ModTime().Equal(rec.ModTime) {
+			continue
+		}
+		records = append(records, fileRecord(fp, info, hash))
+	}
+	return records
+}
+
+// indexFileInfo adapts a cached logRecord to os.FileInfo so indexed entries
+// can be run through the same Predicate chain as a live filesystem walk.
+type indexFileInfo struct{ rec logRecord }
+
+func (f indexFileInfo) Name() string       { return filepath.Base(f.rec.Path) }
+func (f indexFileInfo) Size() int64        { return f.rec.Size }
+func (f indexFileInfo) Mode() os.FileMode  { return 0 }
+func (f indexFileInfo) ModTime() time.Time { return f.rec.ModTime }
+func (f indexFileInfo) IsDir() bool        { return false }
+func (f indexFileInfo) Sys() interface{}   { return nil }
+
+// queryIndex applies predicates to every indexed file without touching the
+// filesystem walk at all - only -contains (if set) still opens files.
+func queryIndex(idx *Index, c Config, predicates []Predicate) []FileResult {
+	var results []FileResult
+	for path, rec := range idx.Files {
+		atomic.AddInt64(&scannedCount, 1)
+
+		info := indexFileInfo{rec}
+		if !isMatch(info, path, predicates) {
+			continue
+		}
+
+		matches, ok := checkContains(path, info, c.Contains)
+		if !ok {
+			continue
+		}
+
+		atomic.AddInt64(&matchCount, 1)
+		result := buildResult(filepath.Dir(path), info, matches)
+		if liveSink != nil {
+			liveSink(result)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// runIndexedSearch loads (and optionally refreshes) the index named by
+// c.UseIndex, then queries it in place of a filesystem walk.
+func runIndexedSearch(c Config, predicates []Predicate) ([]FileResult, error) {
+	idx, err := readIndex(c.UseIndex)
+	if err != nil {
+		return nil, fmt.Errorf("reading index %s: %w", c.UseIndex, err)
+	}
+
+	if c.Refresh {
+		records, err := refreshIndexRecords(idx, false)
+		if err != nil {
+			return nil, fmt.Errorf("refreshing index %s: %w", c.UseIndex, err)
+		}
+		if len(records) > 0 {
+			if err := appendIndex(c.UseIndex, records); err != nil {
+				return nil, fmt.Errorf("writing refreshed index %s: %w", c.UseIndex, err)
+			}
+			for _, rec := range records {
+				idx.apply(rec)
+			}
+		}
+	}
+
+	return queryIndex(idx, c, predicates), nil
+}
+
+// runIndexCommand implements the `index` subcommand: a full, from-scratch
+// index build written to -db.
+func runIndexCommand(args []string) error {
+	fs := flag.NewFlagSet("index", flag.ExitOnError)
+	dir := fs.String("dir", ".", "Directory to index")
+	db := fs.String("db", "index.db", "Path to the index file to write")
+	hash := fs.Bool("hash", false, "Record a sha256 for each file (slower)")
+	fs.Parse(args)
+
+	printInfo(fmt.Sprintf("Indexing %s -> %s", *dir, *db))
+
+	records, err := buildIndexRecords(*dir, *hash)
+	if err != nil {
+		return fmt.Errorf("indexing %s: %w", *dir, err)
+	}
+
+	if err := os.Remove(*db); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("replacing %s: %w", *db, err)
+	}
+	if err := appendIndex(*db, records); err != nil {
+		return fmt.Errorf("writing %s: %w", *db, err)
+	}
+
+	printSuccess(fmt.Sprintf("Indexed %d entries to %s", len(records), *db))
+	return nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestRefreshIndexRecordsDetectsInPlaceEdit exercises the case a directory's
+// own mtime can't signal: an existing file edited in place, with no
+// directory entries added/removed/renamed. Editing a file doesn't move its
+// parent directory's mtime on any common filesystem, so refreshIndexRecords
+// must re-stat known files individually rather than trusting directory
+// mtime alone.
+func TestRefreshIndexRecordsDetectsInPlaceEdit(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "a.txt")
+	if err := os.WriteFile(fp, []byte("123456789"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := buildIndexRecords(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx := newIndex()
+	for _, rec := range records {
+		idx.apply(rec)
+	}
+
+	before, ok := idx.Files[fp]
+	if !ok {
+		t.Fatalf("indexed file %s missing after initial build", fp)
+	}
+	if before.Size != 9 {
+		t.Fatalf("indexed size = %d, want 9", before.Size)
+	}
+
+	// Edit the file in place, then pin the parent directory's mtime back to
+	// exactly what was indexed - simulating the real-world case where the
+	// edit didn't touch the directory's mtime at all.
+	if err := os.WriteFile(fp, []byte("this content is now forty one bytes long!"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dirRec := idx.Dirs[dir]
+	if err := os.Chtimes(dir, dirRec.ModTime, dirRec.ModTime); err != nil {
+		t.Fatal(err)
+	}
+
+	refreshed, err := refreshIndexRecords(idx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rec := range refreshed {
+		idx.apply(rec)
+	}
+
+	after, ok := idx.Files[fp]
+	if !ok {
+		t.Fatalf("indexed file %s missing after refresh", fp)
+	}
+	if after.Size != 41 {
+		t.Errorf("refreshed size = %d, want 41 (refresh missed the in-place edit)", after.Size)
+	}
+}
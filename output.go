@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OutputFormatter renders a result set to a writer. tabular and ndjson are
+// handled separately in handleOutput/main - tabular always goes straight to
+// the terminal with colors, and ndjson streams live as matches are found -
+// everything else registered here is a plain post-scan render.
+type OutputFormatter interface {
+	// Ext is the file extension used to name the output file when the
+	// user didn't give an explicit -out destination.
+	Ext() string
+	Write(w io.Writer, results []FileResult) error
+}
+
+var outputFormatters = map[string]OutputFormatter{
+	"json":  jsonFormatter{},
+	"md":    markdownFormatter{},
+	"csv":   csvFormatter{},
+	"sarif": sarifFormatter{},
+	"html":  htmlFormatter{},
+}
+
+// resolveOutput applies the "-o -" shorthand: passing "-" as the format
+// means "write json to stdout" without also having to pass "-out -", so
+// `filesearcher -o - | jq .` works on its own.
+func resolveOutput(c Config) (format, dest string) {
+	format = strings.ToLower(c.OutputFormat)
+	dest = c.Out
+	if format == "-" {
+		format = "json"
+		dest = "-"
+	}
+	return format, dest
+}
+
+// handleOutput routes to the formatter named by c.OutputFormat, writing to
+// c.Out (or an auto-generated filename) unless c.Out is "-", which means
+// stdout - so the tool composes with shell pipelines. c.OutputFormat/c.Out
+// are expected to already be resolved (see resolveOutput) by the time this
+// is called.
+func handleOutput(results []FileResult, c Config) {
+	format := strings.ToLower(c.OutputFormat)
+
+	if format == "" || format == "tabular" {
+		printTabular(results)
+		return
+	}
+	if format == "ndjson" {
+		// Already streamed to its destination as matches were found.
+		return
+	}
+
+	formatter, ok := outputFormatters[format]
+	if !ok {
+		printWarning(fmt.Sprintf("Unknown output format %q, falling back to tabular", c.OutputFormat))
+		printTabular(results)
+		return
+	}
+
+	dest := c.Out
+	if dest == "" {
+		dest = generateFilename("output", c.Extensions, formatter.Ext())
+	}
+
+	w, closeFn, err := openDest(dest)
+	if err != nil {
+		printError(fmt.Sprintf("Error opening %s: %v", dest, err))
+		return
+	}
+	defer closeFn()
+
+	if err := formatter.Write(w, results); err != nil {
+		printError(fmt.Sprintf("Error writing %s output: %v", format, err))
+		return
+	}
+
+	if dest != "-" {
+		printSuccess(fmt.Sprintf("%s saved to: %s", strings.ToUpper(format), dest))
+	}
+}
+
+// openDest opens dest for writing, treating "-" as stdout (in which case
+// closeFn is a no-op, since we don't own stdout).
+func openDest(dest string) (w io.Writer, closeFn func(), err error) {
+	if dest == "-" {
+		return os.Stdout, func() {}, nil
+	}
+	file, err := os.Create(dest)
+	if err != nil {
+		return nil, nil, err
+	}
+	return file, func() { file.Close() }, nil
+}
+
+type jsonFormatter struct{}
+
+func (jsonFormatter) Ext() string { return "json" }
+
+func (jsonFormatter) Write(w io.Writer, results []FileResult) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
+
+type markdownFormatter struct{}
+
+func (markdownFormatter) Ext() string { return "md" }
+
+func (markdownFormatter) Write(w io.Writer, results []FileResult) error {
+	fmt.Fprintln(w, "# ðŸ” File Search Results")
+	fmt.Fprintf(w, "**Generated:** %s\n\n", time.Now().Format(time.RFC1123))
+	fmt.Fprintf(w, "**Total Files Found:** %d\n\n", len(results))
+	fmt.Fprintln(w, "| Last Modified | Size | File Name | Full Path |")
+	fmt.Fprintln(w, "|---|---|---|---|")
+
+	for _, r := range results {
+		safePath := strings.ReplaceAll(r.Path, "|", "\\|")
+		fmt.Fprintf(w, "| %s | %s | %s | %s |\n", r.LastModified, formatSize(r.Size), r.Name, safePath)
+	}
+	return nil
+}
+
+type csvFormatter struct{}
+
+func (csvFormatter) Ext() string { return "csv" }
+
+func (csvFormatter) Write(w io.Writer, results []FileResult) error {
+	cw := csv.NewWriter(w)
+	defer cw.Flush()
+
+	if err := cw.Write([]string{"name", "path", "last_modified", "size_bytes", "first_match"}); err != nil {
+		return err
+	}
+	for _, r := range results {
+		if err := cw.Write([]string{
+			r.Name,
+			r.Path,
+			r.LastModified,
+			strconv.FormatInt(r.Size, 10),
+			firstMatchPreview(r.Matches),
+		}); err != nil {
+			return err
+		}
+	}
+	return cw.Error()
+}
+
+// ndjsonFormatter exists only so -o ndjson reports a sensible extension
+// when generating an output filename is needed before the live sink opens
+// it; actual writing happens in ndjsonSink (see output_ndjson.go).
+type ndjsonFormatter struct{}
+
+func (ndjsonFormatter) Ext() string { return "ndjson" }
+
+func (ndjsonFormatter) Write(io.Writer, []FileResult) error { return nil }
+
+func generateFilename(prefix, exts, suffix string) string {
+	extPart := "all"
+	if exts != "" {
+		extPart = strings.ReplaceAll(exts, ",", "_")
+	}
+	return fmt.Sprintf("%s_%s.%s", prefix, extPart, suffix)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+type htmlFormatter struct{}
+
+func (htmlFormatter) Ext() string { return "html" }
+
+// Write renders a single self-contained HTML page: results are embedded as
+// a JSON blob and a small inline script builds a table that supports
+// column sort and a substring filter box, entirely client-side.
+func (htmlFormatter) Write(w io.Writer, results []FileResult) error {
+	data, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	// Guard against the JSON blob breaking out of the <script> tag.
+	safe := strings.ReplaceAll(string(data), "</", "<\\/")
+
+	page := strings.Replace(htmlPageTemplate, "__DATA__", safe, 1)
+	_, err = io.WriteString(w, page)
+	return err
+}
+
+const htmlPageTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>File Search Results</title>
+<style>
+  body { font-family: system-ui, sans-serif; margin: 2rem; color: #1a1a1a; }
+  input { padding: 0.4rem; width: 100%; max-width: 24rem; margin-bottom: 1rem; }
+  table { border-collapse: collapse; width: 100%; }
+  th, td { text-align: left; padding: 0.4rem 0.8rem; border-bottom: 1px solid #ddd; }
+  th { cursor: pointer; user-select: none; background: #f5f5f5; }
+  th.sorted::after { content: " \25BE"; }
+  tr:hover { background: #fafafa; }
+</style>
+</head>
+<body>
+<h1>File Search Results</h1>
+<input id="filter" type="text" placeholder="Filter by name or path...">
+<table id="results">
+  <thead>
+    <tr>
+      <th data-key="name">Name</th>
+      <th data-key="path">Path</th>
+      <th data-key="last_modified">Last Modified</th>
+      <th data-key="size_bytes">Size (bytes)</th>
+    </tr>
+  </thead>
+  <tbody></tbody>
+</table>
+<script>
+const DATA = __DATA__;
+const tbody = document.querySelector('#results tbody');
+let sortKey = 'name', sortAsc = true;
+
+function addCell(tr, text) {
+  const td = document.createElement('td');
+  td.textContent = text;
+  tr.appendChild(td);
+}
+
+function render(rows) {
+  tbody.innerHTML = '';
+  for (const r of rows) {
+    const tr = document.createElement('tr');
+    addCell(tr, r.name);
+    addCell(tr, r.path);
+    addCell(tr, r.last_modified);
+    addCell(tr, r.size_bytes);
+    tbody.appendChild(tr);
+  }
+}
+
+function apply() {
+  const q = document.querySelector('#filter').value.toLowerCase();
+  let rows = DATA.filter(r => !q || r.name.toLowerCase().includes(q) || r.path.toLowerCase().includes(q));
+  rows.sort((a, b) => {
+    const av = a[sortKey], bv = b[sortKey];
+    const cmp = av < bv ? -1 : av > bv ? 1 : 0;
+    return sortAsc ? cmp : -cmp;
+  });
+  render(rows);
+}
+
+document.querySelectorAll('th[data-key]').forEach(th => {
+  th.addEventListener('click', () => {
+    const key = th.dataset.key;
+    sortAsc = (sortKey === key) ? !sortAsc : true;
+    sortKey = key;
+    document.querySelectorAll('th').forEach(h => h.classList.remove('sorted'));
+    th.classList.add('sorted');
+    apply();
+  });
+});
+document.querySelector('#filter').addEventListener('input', apply);
+
+apply();
+</script>
+</body>
+</html>
+`
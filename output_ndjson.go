@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// liveSink, when non-nil, is called once per match as soon as it's found -
+// not just at the end of the scan. It exists for -o ndjson, where the
+// whole point is that the output file is usable mid-scan rather than only
+// after the walk finishes.
+var liveSink func(FileResult)
+
+// ndjsonSink streams one JSON object per line to its destination, flushing
+// after every write so a `tail -f` (or a consumer reading mid-scan) sees
+// matches as they happen.
+type ndjsonSink struct {
+	w    io.Writer
+	file *os.File // nil when writing to stdout
+	enc  *json.Encoder
+}
+
+func newNDJSONSink(c Config) (*ndjsonSink, error) {
+	dest := c.Out
+	if dest == "" {
+		dest = generateFilename("output", c.Extensions, ndjsonFormatter{}.Ext())
+	}
+
+	if dest == "-" {
+		return &ndjsonSink{w: os.Stdout, enc: json.NewEncoder(os.Stdout)}, nil
+	}
+
+	file, err := os.Create(dest)
+	if err != nil {
+		return nil, err
+	}
+	return &ndjsonSink{w: file, file: file, enc: json.NewEncoder(file)}, nil
+}
+
+func (s *ndjsonSink) write(r FileResult) {
+	if err := s.enc.Encode(r); err != nil {
+		return
+	}
+	if s.file != nil {
+		s.file.Sync()
+	}
+}
+
+func (s *ndjsonSink) destination() string {
+	if s.file == nil {
+		return "-"
+	}
+	return s.file.Name()
+}
+
+func (s *ndjsonSink) Close() error {
+	if s.file == nil {
+		return nil
+	}
+	return s.file.Close()
+}
+
+func (s *ndjsonSink) announce() {
+	if s.file != nil {
+		printSuccess(fmt.Sprintf("NDJSON streamed to: %s", s.file.Name()))
+	}
+}